@@ -0,0 +1,97 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bridge fans sensormesh whispers out to a NATS subject (and back
+// in again), so sensors and aggregators that don't speak IPFS can still
+// join the mesh.
+package bridge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Mode controls which direction of traffic a Bridge carries.
+type Mode string
+
+const (
+	ModePublish   Mode = "publish"
+	ModeSubscribe Mode = "subscribe"
+	ModeBoth      Mode = "both"
+)
+
+// Bridge ferries payloads between the log store and a NATS subject.
+type Bridge struct {
+	conn    *nats.Conn
+	subject string
+	mode    Mode
+}
+
+// Connect dials url and returns a Bridge that publishes/subscribes on
+// subject according to mode, which must be one of ModePublish,
+// ModeSubscribe or ModeBoth.
+func Connect(url, subject string, mode Mode) (*Bridge, error) {
+	switch mode {
+	case ModePublish, ModeSubscribe, ModeBoth:
+	default:
+		return nil, fmt.Errorf("invalid nats.mode %q: must be %q, %q or %q", mode, ModePublish, ModeSubscribe, ModeBoth)
+	}
+
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats at %s: %s", url, err)
+	}
+	return &Bridge{conn: conn, subject: subject, mode: mode}, nil
+}
+
+// Publish sends payload to the bridge's subject, unless the bridge was
+// configured subscribe-only.
+func (b *Bridge) Publish(payload []byte) error {
+	if b.mode == ModeSubscribe {
+		return nil
+	}
+	return b.conn.Publish(b.subject, payload)
+}
+
+// Subscribe invokes onMessage for every message received on the bridge's
+// subject, until ctx is cancelled. It's a no-op if the bridge was
+// configured publish-only.
+func (b *Bridge) Subscribe(ctx context.Context, onMessage func(payload []byte)) error {
+	if b.mode == ModePublish {
+		return nil
+	}
+
+	sub, err := b.conn.Subscribe(b.subject, func(msg *nats.Msg) {
+		onMessage(msg.Data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to nats subject %s: %s", b.subject, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = sub.Unsubscribe()
+	}()
+
+	return nil
+}
+
+// Close closes the underlying NATS connection without draining it first.
+func (b *Bridge) Close() {
+	b.conn.Close()
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sensormesh/cmd/shared"
+
+	"berty.tech/go-orbit-db/iface"
+	"github.com/spf13/cobra"
+)
+
+var dumpStoreAddress string
+var dumpEmbedded bool
+
+// dumpCmd streams the entire log store to stdout as newline-delimited
+// JSON, for offline analysis with jq or similar.
+var dumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Stream the entire log store to stdout as NDJSON",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shared.RequireConfig()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		store, closeStore, err := shared.OpenStore(ctx, dumpStoreAddress, dumpEmbedded)
+		if err != nil {
+			return err
+		}
+		defer closeStore()
+
+		// A nil Amount only yields the newest entry; -1 asks for all of them.
+		all := -1
+		ops, err := store.List(ctx, &iface.StreamOptions{Amount: &all})
+		if err != nil {
+			return fmt.Errorf("failed to get list from log store: %s", err)
+		}
+
+		// List returns newest first; dump in the order entries were written.
+		for i := len(ops) - 1; i >= 0; i-- {
+			if _, err := os.Stdout.Write(append(ops[i].GetValue(), '\n')); err != nil {
+				return fmt.Errorf("failed to write entry to stdout: %s", err)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dumpCmd)
+	dumpCmd.Flags().StringVar(&dumpStoreAddress, "storeaddress", "event", "Address of the log store. Defaults to create a new log store with name 'event'")
+	dumpCmd.Flags().BoolVar(&dumpEmbedded, "embedded", false, "Run an embedded IPFS node instead of dialing an external ipfs daemon's HTTP API")
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sensormesh/cmd/shared"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var publishStoreAddress string
+var publishEmbedded bool
+
+func publish() {
+	defer cancel()
+	ticker := time.NewTicker(shared.Live.PublishInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-publishReload:
+			ticker.Stop()
+			ticker = time.NewTicker(shared.Live.PublishInterval())
+		case <-ticker.C:
+			// TODO - Get cam from Vanetza, use .RawJSON
+			logger.Info().
+				Str("type", "whisper").
+				Str("name", shared.ViperConfs.GetString("name")).
+				Send()
+
+			// Posting new value to the log store
+			payload := logbuf.Bytes()
+			_, err := logStore.Add(ctx, payload)
+			if err != nil {
+				panic(fmt.Errorf("failed to put in log store: %s", err))
+			}
+
+			if natsBridge != nil {
+				if err := natsBridge.Publish(payload); err != nil {
+					fmt.Println("[!] Failed to publish whisper to nats: " + err.Error())
+				}
+			}
+
+			// Reset reading buffer
+			logbuf.Reset()
+		}
+	}
+}
+
+// publishCmd represents a sensormesh node that only whispers its own
+// readings into the mesh, without reading back what others wrote.
+var publishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Whisper sensor readings into the mesh, without subscribing to others'",
+	Long: `'sensormesh publish' runs a pure publisher: it bootstraps a log
+store like 'sensormesh run' does, but only ever writes to it. Useful for
+lightweight sensor nodes that don't need to aggregate the rest of the
+mesh's traffic locally.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		shared.RequireConfig()
+
+		var closeStore func()
+		var err error
+		ctx, cancel = context.WithCancel(context.Background())
+		logStore, closeStore, err = shared.OpenStore(ctx, publishStoreAddress, publishEmbedded)
+		if err != nil {
+			panic(err)
+		}
+
+		// Optionally bridge the log store to a NATS subject, so non-IPFS
+		// sensors and aggregators can still join the mesh.
+		natsBridge, err = shared.OpenBridge(ctx, logStore)
+		if err != nil {
+			panic(err)
+		}
+
+		shared.WatchForChanges(notifyReload)
+
+		go func() {
+			<-ctx.Done()
+			if natsBridge != nil {
+				natsBridge.Close()
+			}
+			closeStore()
+		}()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		go publish()
+
+		fmt.Println("[+] Press Ctrl+c to stop publishing")
+
+		sigint := make(chan os.Signal, 1)
+		signal.Notify(sigint, os.Interrupt)
+		<-sigint
+
+		fmt.Println("\n[!] Interrupt signal received, terminating...")
+		cancel()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(publishCmd)
+	publishCmd.Flags().StringVar(&publishStoreAddress, "storeaddress", "event", "Address of the log store. Defaults to create a new log store with name 'event'")
+	publishCmd.Flags().BoolVar(&publishEmbedded, "embedded", false, "Run an embedded IPFS node instead of dialing an external ipfs daemon's HTTP API")
+}
@@ -0,0 +1,103 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sensormesh/cmd/shared"
+
+	"github.com/spf13/cobra"
+)
+
+var runStoreAddress string
+var runEmbedded bool
+
+// runCmd represents the full sensormesh daemon: both a publisher and a
+// subscriber against the same log store. 'daemon' is kept as an alias for
+// backwards compatibility with earlier sensormesh releases.
+var runCmd = &cobra.Command{
+	Use:     "run",
+	Aliases: []string{"daemon"},
+	Short:   "Run a OrbitDB sensor logger that both publishes and subscribes",
+	Long: `'sensormesh run' runs a persistent sensormesh daemon that can
+query specified sensor and log their responses to a OrbitDB
+log file, that will be shared between nodes in a same IPFS
+private network.
+
+The daemon will start by first configuring the current
+machine as a node in a private IPFS network, and then
+initialize IPFS's daemon`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		shared.RequireConfig()
+
+		ctx, cancel = context.WithCancel(context.Background())
+
+		var (
+			closeStore func()
+			err        error
+		)
+		logStore, closeStore, err = shared.OpenStore(ctx, runStoreAddress, runEmbedded)
+		if err != nil {
+			panic(err)
+		}
+
+		// Optionally bridge the log store to a NATS subject, so non-IPFS
+		// sensors and aggregators can still join the mesh.
+		natsBridge, err = shared.OpenBridge(ctx, logStore)
+		if err != nil {
+			panic(err)
+		}
+
+		// Watch the config file for edits pushed by a fleet manager and
+		// apply the safe-to-change fields (logfile, store address, publish
+		// interval) without requiring a restart.
+		shared.WatchForChanges(notifyReload)
+
+		go func() {
+			<-ctx.Done()
+			if natsBridge != nil {
+				natsBridge.Close()
+			}
+			closeStore()
+		}()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		// Initiate reading and writing to the database as a multi-threaded processes
+		go publish()
+		go subscribe()
+
+		fmt.Println("[+] Press Ctrl+c to stop daemon")
+
+		// Capture SIGINT
+		sigint := make(chan os.Signal, 1)
+		signal.Notify(sigint, os.Interrupt)
+
+		// Wait for an interrupt signal
+		<-sigint
+
+		fmt.Println("\n[!] Interrupt signal received, terminating...")
+		cancel()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.Flags().StringVar(&runStoreAddress, "storeaddress", "event", "Address of the log store. Defaults to create a new log store with name 'event'")
+	runCmd.Flags().BoolVar(&runEmbedded, "embedded", false, "Run an embedded IPFS node instead of dialing an external ipfs daemon's HTTP API")
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sensormesh/cmd/shared"
+
+	"berty.tech/go-orbit-db/iface"
+	"github.com/spf13/cobra"
+)
+
+var statusStoreAddress string
+var statusEmbedded bool
+
+// statusCmd reports on a log store without joining it as a long-running
+// publisher or subscriber.
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print the store address, connected peers and last entry timestamp",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		shared.RequireConfig()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		store, closeStore, err := shared.OpenStore(ctx, statusStoreAddress, statusEmbedded)
+		if err != nil {
+			return err
+		}
+		defer closeStore()
+
+		fmt.Println("store:", store.DBName())
+		fmt.Println("address:", store.Address().String())
+
+		if peers, err := store.IPFS().Swarm().Peers(ctx); err != nil {
+			fmt.Println("peers: unavailable (" + err.Error() + ")")
+		} else {
+			fmt.Printf("peers: %d\n", len(peers))
+		}
+
+		ops, err := store.List(ctx, &iface.StreamOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get list from log store: %s", err)
+		}
+		if len(ops) == 0 {
+			fmt.Println("last entry: none")
+			return nil
+		}
+
+		var entry struct {
+			Time string `json:"time"`
+		}
+		if err := json.Unmarshal(ops[0].GetValue(), &entry); err == nil && entry.Time != "" {
+			fmt.Println("last entry:", entry.Time)
+		} else {
+			fmt.Println("last entry: unable to parse timestamp from payload")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().StringVar(&statusStoreAddress, "storeaddress", "event", "Address of the log store. Defaults to create a new log store with name 'event'")
+	statusCmd.Flags().BoolVar(&statusEmbedded, "embedded", false, "Run an embedded IPFS node instead of dialing an external ipfs daemon's HTTP API")
+}
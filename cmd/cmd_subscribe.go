@@ -0,0 +1,201 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sensormesh/cmd/shared"
+	"sensormesh/cmd/sinks"
+	"strings"
+
+	ipfslog "berty.tech/go-ipfs-log"
+	"berty.tech/go-orbit-db/stores"
+	"berty.tech/go-orbit-db/stores/operation"
+	"github.com/spf13/cobra"
+)
+
+var subscribeStoreAddress string
+var subscribeEmbedded bool
+
+func subscribe() {
+	defer cancel()
+
+	for {
+		exit, err := runSubscription()
+		if err != nil {
+			panic(err)
+		}
+		if exit {
+			return
+		}
+	}
+}
+
+// loadSinks builds the sinks declared under the `sinks` config key. If
+// none are declared, it falls back to a single unfiltered file sink
+// pointed at logfile, preserving sensormesh's historical behaviour.
+func loadSinks() ([]*sinks.Routed, error) {
+	var specs []sinks.Spec
+	if err := shared.ViperConfs.UnmarshalKey("sinks", &specs); err != nil {
+		return nil, fmt.Errorf("failed to parse sinks config: %s", err)
+	}
+
+	if len(specs) == 0 {
+		specs = []sinks.Spec{{
+			Type:    "file",
+			Options: map[string]interface{}{"path": shared.Live.LogFile()},
+		}}
+	}
+
+	return sinks.Build(specs)
+}
+
+// runSubscription fans out every entry the store sees to the configured
+// sinks, until either the daemon is stopping (exit == true) or
+// logfile/orbitdb.storeaddress changed under it (exit == false, so
+// subscribe() rebuilds the sinks and log store and loops again).
+func runSubscription() (exit bool, err error) {
+	logFile := shared.Live.LogFile()
+	routedSinks, err := loadSinks()
+	if err != nil {
+		return true, err
+	}
+	defer sinks.CloseAll(routedSinks)
+	fmt.Printf("[+] Fanning out log store entries to %d sink(s)\n", len(routedSinks))
+
+	currentStoreAddress := logStore.Address().String()
+
+	// Subscribe to the store's event bus instead of polling List(), so we
+	// neither busy-loop nor lose entries that get overwritten between reads.
+	sub, err := logStore.EventBus().Subscribe([]interface{}{new(stores.EventWrite), new(stores.EventReplicated)})
+	if err != nil {
+		return true, fmt.Errorf("failed to subscribe to log store events: %s", err)
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true, nil
+		case evt, ok := <-sub.Out():
+			if !ok {
+				return true, nil
+			}
+			dispatchEvent(routedSinks, evt)
+		case <-subscribeReload:
+			if shared.Live.LogFile() != logFile {
+				return false, nil
+			}
+			if shared.Live.StoreAddress() != "" && shared.Live.StoreAddress() != currentStoreAddress {
+				store, err := shared.ReopenStore(ctx, shared.Live.StoreAddress())
+				if err != nil {
+					return true, err
+				}
+				logStore = store
+				return false, nil
+			}
+		}
+	}
+}
+
+// dispatchEvent decodes the entries carried on a store write/replication
+// event and fans each of them, in order, out to the matching sinks.
+func dispatchEvent(routedSinks []*sinks.Routed, evt interface{}) {
+	var logEntries []ipfslog.Entry
+	switch e := evt.(type) {
+	case stores.EventWrite:
+		logEntries = []ipfslog.Entry{e.Entry}
+	case stores.EventReplicated:
+		logEntries = e.Entries
+	}
+
+	var entries [][]byte
+	for _, logEntry := range logEntries {
+		op, err := operation.ParseOperation(logEntry)
+		if err != nil {
+			fmt.Println("[!] Failed to parse log store entry: " + err.Error())
+			continue
+		}
+		entries = append(entries, op.GetValue())
+	}
+
+	for _, entry := range entries {
+		entry := []byte(strings.TrimRight(string(entry), "\n"))
+		for _, err := range sinks.Dispatch(routedSinks, entry) {
+			fmt.Println("[!] " + err.Error())
+		}
+	}
+}
+
+// subscribeCmd represents a sensormesh node that only aggregates what the
+// rest of the mesh writes, without publishing its own readings.
+var subscribeCmd = &cobra.Command{
+	Use:   "subscribe",
+	Short: "Log whatever the mesh writes, without publishing readings of your own",
+	Long: `'sensormesh subscribe' runs a pure subscriber: it bootstraps a log
+store like 'sensormesh run' does, but only ever reads from it. Useful for
+aggregator nodes that collect readings from a fleet of publish-only
+sensors.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		shared.RequireConfig()
+
+		var closeStore func()
+		var err error
+		ctx, cancel = context.WithCancel(context.Background())
+		logStore, closeStore, err = shared.OpenStore(ctx, subscribeStoreAddress, subscribeEmbedded)
+		if err != nil {
+			panic(err)
+		}
+
+		// Optionally bridge the log store to a NATS subject, so non-IPFS
+		// sensors and aggregators can still join the mesh.
+		natsBridge, err = shared.OpenBridge(ctx, logStore)
+		if err != nil {
+			panic(err)
+		}
+
+		shared.WatchForChanges(notifyReload)
+
+		go func() {
+			<-ctx.Done()
+			if natsBridge != nil {
+				natsBridge.Close()
+			}
+			closeStore()
+		}()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		go subscribe()
+
+		fmt.Println("[+] Press Ctrl+c to stop subscribing")
+
+		sigint := make(chan os.Signal, 1)
+		signal.Notify(sigint, os.Interrupt)
+		<-sigint
+
+		fmt.Println("\n[!] Interrupt signal received, terminating...")
+		cancel()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(subscribeCmd)
+	subscribeCmd.Flags().StringVar(&subscribeStoreAddress, "storeaddress", "event", "Address of the log store. Defaults to create a new log store with name 'event'")
+	subscribeCmd.Flags().BoolVar(&subscribeEmbedded, "embedded", false, "Run an embedded IPFS node instead of dialing an external ipfs daemon's HTTP API")
+}
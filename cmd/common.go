@@ -0,0 +1,55 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"bytes"
+	"context"
+
+	"berty.tech/go-orbit-db/iface"
+	"github.com/rs/zerolog"
+	"sensormesh/cmd/bridge"
+)
+
+// State shared by the run/publish/subscribe commands, all of which bootstrap
+// through shared.OpenStore and then drive the resulting log store.
+var (
+	ctx      context.Context
+	cancel   context.CancelFunc
+	logbuf   bytes.Buffer
+	logger   zerolog.Logger
+	logStore iface.EventLogStore
+
+	natsBridge *bridge.Bridge
+
+	// publishReload/subscribeReload notify publish() and subscribe() that
+	// shared.Live has been refreshed and they should pick up the new values.
+	publishReload   = make(chan struct{}, 1)
+	subscribeReload = make(chan struct{}, 1)
+)
+
+func notifyReload() {
+	for _, ch := range []chan struct{}{publishReload, subscribeReload} {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func init() {
+	logger = zerolog.New(&logbuf).With().Timestamp().Logger()
+}
@@ -0,0 +1,56 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"berty.tech/go-orbit-db/iface"
+	"sensormesh/cmd/bridge"
+)
+
+// OpenBridge connects to the NATS bridge configured under nats.url/
+// nats.subject/nats.mode, if any, and wires it to store so a non-IPFS
+// sensor can feed the mesh. It's a no-op (nil, nil) when nats.url isn't
+// set, and is shared by every subcommand that bootstraps a log store
+// (run, publish, subscribe), not just the combined daemon.
+func OpenBridge(ctx context.Context, store iface.EventLogStore) (*bridge.Bridge, error) {
+	natsURL := ViperConfs.GetString("nats.url")
+	if natsURL == "" {
+		return nil, nil
+	}
+
+	subject := ViperConfs.GetString("nats.subject")
+	mode := bridge.Mode(ViperConfs.GetString("nats.mode"))
+
+	b, err := bridge.Connect(natsURL, subject, mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats bridge: %s", err)
+	}
+	fmt.Println("[+] Bridging log store to NATS subject " + subject + " at " + natsURL)
+
+	err = b.Subscribe(ctx, func(payload []byte) {
+		if _, err := store.Add(ctx, payload); err != nil {
+			fmt.Println("[!] Failed to add nats message to log store: " + err.Error())
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to nats bridge: %s", err)
+	}
+
+	return b, nil
+}
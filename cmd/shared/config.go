@@ -0,0 +1,91 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shared holds the state and helpers that every sensormesh
+// subcommand needs: configuration, IPFS connection details and the
+// local repo layout.
+package shared
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	// ConfigFilePath is the on-disk location of sensormesh's config file.
+	ConfigFilePath = filepath.Join(configDir(), "config.yaml")
+
+	// RepoPath is the on-disk location of sensormesh's private IPFS repo.
+	RepoPath = filepath.Join(configDir(), "ipfs")
+
+	// ViperConfs is the process-wide handle to the loaded configuration.
+	ViperConfs = viper.New()
+)
+
+func configDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".sensormesh")
+}
+
+// Exists reports whether the file at path exists, returning the error
+// from os.Stat for anything other than "not found".
+func Exists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// LoadConfigurationFromFile reads ConfigFilePath into ViperConfs, creating
+// it with sane defaults first if it doesn't exist yet.
+func LoadConfigurationFromFile() {
+	ViperConfs.SetConfigFile(ConfigFilePath)
+	ViperConfs.SetConfigType("yaml")
+
+	if ok, _ := Exists(ConfigFilePath); !ok {
+		setDefaults()
+		if err := os.MkdirAll(filepath.Dir(ConfigFilePath), 0755); err != nil {
+			panic(err)
+		}
+		if err := ViperConfs.WriteConfigAs(ConfigFilePath); err != nil {
+			panic(err)
+		}
+	}
+
+	if err := ViperConfs.ReadInConfig(); err != nil {
+		panic(err)
+	}
+}
+
+func setDefaults() {
+	ViperConfs.SetDefault("ipfs.apiaddress", "/ip4/127.0.0.1/tcp/5001")
+	ViperConfs.SetDefault("logfile", "sensormesh.log")
+}
+
+// LocalIPFSApiAddress returns the multiaddr of the IPFS HTTP API sensormesh
+// should dial, as configured under ipfs.apiaddress.
+func LocalIPFSApiAddress() string {
+	return ViperConfs.GetString("ipfs.apiaddress")
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package shared
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ipfs/go-ipfs-config"
+	"github.com/ipfs/kubo/core"
+	"github.com/ipfs/kubo/core/coreapi"
+	"github.com/ipfs/kubo/core/node/libp2p"
+	"github.com/ipfs/kubo/plugin/loader"
+	"github.com/ipfs/kubo/repo/fsrepo"
+	coreiface "github.com/ipfs/interface-go-ipfs-core"
+)
+
+// StartEmbeddedIPFS brings up an in-process IPFS node rooted at repoPath,
+// initializing the repo on first run, and returns a CoreAPI that can be
+// handed to orbitdb.NewOrbitDB exactly like the HTTP client would be.
+func StartEmbeddedIPFS(ctx context.Context, repoPath string) (coreiface.CoreAPI, *core.IpfsNode, error) {
+	plugins, err := loader.NewPluginLoader(repoPath + "/plugins")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load plugins: %s", err)
+	}
+	if err := plugins.Initialize(); err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize plugins: %s", err)
+	}
+	if err := plugins.Inject(); err != nil {
+		return nil, nil, fmt.Errorf("failed to inject plugins: %s", err)
+	}
+
+	if ok, _ := Exists(repoPath); !ok {
+		if err := initRepo(repoPath); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	repo, err := fsrepo.Open(repoPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open ipfs repo at %s: %s", repoPath, err)
+	}
+
+	node, err := core.NewNode(ctx, &core.BuildCfg{
+		Online:  true,
+		Routing: libp2p.DHTOption,
+		Repo:    repo,
+		ExtraOpts: map[string]bool{
+			"pubsub": true,
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build embedded ipfs node: %s", err)
+	}
+
+	api, err := coreapi.NewCoreAPI(node)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to wrap embedded ipfs node in core api: %s", err)
+	}
+
+	return api, node, nil
+}
+
+func initRepo(repoPath string) error {
+	// config.Init writes key-generation progress to this writer unconditionally.
+	cfg, err := config.Init(os.Stdout, 2048)
+	if err != nil {
+		return fmt.Errorf("failed to generate default ipfs config: %s", err)
+	}
+	if err := fsrepo.Init(repoPath, cfg); err != nil {
+		return fmt.Errorf("failed to init ipfs repo at %s: %s", repoPath, err)
+	}
+	return nil
+}
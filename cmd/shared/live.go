@@ -0,0 +1,81 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package shared
+
+import (
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// liveConfig holds the subset of configuration that's safe to change while
+// the daemon is running, guarded by a mutex so publish() and subscribe()
+// always see a consistent snapshot instead of racing the config reload.
+type liveConfig struct {
+	mu              sync.RWMutex
+	logFile         string
+	storeAddress    string
+	publishInterval time.Duration
+}
+
+// Live is the process-wide handle to the hot-reloadable configuration.
+var Live = &liveConfig{}
+
+func (l *liveConfig) refresh() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logFile = ViperConfs.GetString("logfile")
+	l.storeAddress = ViperConfs.GetString("orbitdb.storeaddress")
+	l.publishInterval = ViperConfs.GetDuration("publish.interval")
+}
+
+func (l *liveConfig) LogFile() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.logFile
+}
+
+func (l *liveConfig) StoreAddress() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.storeAddress
+}
+
+func (l *liveConfig) PublishInterval() time.Duration {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.publishInterval > 0 {
+		return l.publishInterval
+	}
+	return 30 * time.Second
+}
+
+// WatchForChanges snapshots the current configuration into Live and then
+// watches ConfigFilePath for further edits, re-snapshotting and invoking
+// onChange every time it's rewritten. onChange is called after Live has
+// already been refreshed, so callers can read the new values straight away.
+func WatchForChanges(onChange func()) {
+	Live.refresh()
+
+	ViperConfs.OnConfigChange(func(e fsnotify.Event) {
+		Live.refresh()
+		if onChange != nil {
+			onChange()
+		}
+	})
+	ViperConfs.WatchConfig()
+}
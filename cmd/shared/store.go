@@ -0,0 +1,114 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package shared
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	orbitdb "berty.tech/go-orbit-db"
+	"berty.tech/go-orbit-db/accesscontroller"
+	"berty.tech/go-orbit-db/iface"
+	ipfscore "github.com/ipfs/kubo/core"
+	client "github.com/ipfs/go-ipfs-http-client"
+	coreiface "github.com/ipfs/interface-go-ipfs-core"
+)
+
+var (
+	db         orbitdb.OrbitDB
+	accessCtrl = &accesscontroller.CreateAccessControllerOptions{
+		Access: map[string][]string{
+			"write": {"*"},
+			"read":  {"*"},
+		},
+	}
+)
+
+// RequireConfig makes sure a sensormesh config file exists and loads it,
+// the way every subcommand's PreRun wants to start.
+func RequireConfig() {
+	if _, err := Exists(ConfigFilePath); err != nil {
+		panic(fmt.Errorf("configuration file not set. Try running 'sensormesh init' first: %s", err))
+	}
+	LoadConfigurationFromFile()
+}
+
+// OpenStore bootstraps an IPFS connection (embedded when embedded or the
+// ipfs.embedded config key is set, over HTTP otherwise) and OrbitDB
+// instance, and opens/creates the log store at
+// storeAddress. The returned func shuts the embedded IPFS node down (a
+// no-op when an external daemon was used) and should be deferred by the
+// caller.
+func OpenStore(ctx context.Context, storeAddress string, embedded bool) (iface.EventLogStore, func(), error) {
+	var (
+		shell coreiface.CoreAPI
+		node  *ipfscore.IpfsNode
+		err   error
+	)
+
+	if embedded || ViperConfs.GetBool("ipfs.embedded") {
+		fmt.Println("[+] Starting embedded IPFS node at " + RepoPath)
+		shell, node, err = StartEmbeddedIPFS(ctx, RepoPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to start embedded ipfs node: %s", err)
+		}
+	} else {
+		shell, err = client.NewURLApiWithClient(LocalIPFSApiAddress(), &http.Client{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to local IPFS API. IPFS daemon must be running with '--enable-pubsub-experiment': %s", err)
+		}
+		fmt.Println("[+] Connecting to " + ViperConfs.GetString("name") + "'s local IPFS API at " + LocalIPFSApiAddress())
+	}
+
+	db, err = orbitdb.NewOrbitDB(ctx, shell, &orbitdb.NewOrbitDBOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create new orbitdb. IPFS daemon must be running with '--enable-pubsub-experiment': %s", err)
+	}
+
+	store, err := ReopenStore(ctx, storeAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	closeFn := func() {
+		if node != nil {
+			if err := node.Close(); err != nil {
+				fmt.Println("[!] Failed to shut down embedded IPFS node cleanly: " + err.Error())
+			}
+		}
+	}
+
+	return store, closeFn, nil
+}
+
+// ReopenStore points the daemon at a different log store address, reusing
+// the OrbitDB instance OpenStore already set up. It's used at startup and
+// whenever orbitdb.storeaddress is hot-reloaded.
+func ReopenStore(ctx context.Context, storeAddress string) (iface.EventLogStore, error) {
+	store, err := db.Log(ctx, storeAddress, &orbitdb.CreateDBOptions{
+		AccessController: accessCtrl,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log store: %s", err)
+	}
+	fmt.Printf("[🗸] Connected to %s store with address: %s\n", store.DBName(), store.Address().String())
+	ViperConfs.Set("orbitdb.storeaddress", store.Address().String())
+	if err := ViperConfs.WriteConfig(); err != nil {
+		return nil, fmt.Errorf("error updating config file: %v", err)
+	}
+	return store, nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sinks
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+// FileSink appends each entry, one per line, to a local file.
+type FileSink struct {
+	file   *os.File
+	writer *log.Logger
+}
+
+func newFileSink(options map[string]interface{}) (Sink, error) {
+	path := stringOption(options, "path", "")
+	if path == "" {
+		return nil, fmt.Errorf("file sink requires a \"path\" option")
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sink file %s: %s", path, err)
+	}
+
+	return &FileSink{file: file, writer: log.New(file, "", 0)}, nil
+}
+
+func (s *FileSink) Write(entry []byte) error {
+	s.writer.Println(string(entry))
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
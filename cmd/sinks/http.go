@@ -0,0 +1,58 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sinks
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPSink POSTs each entry as JSON to a webhook URL.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPSink(options map[string]interface{}) (Sink, error) {
+	url := stringOption(options, "url", "")
+	if url == "" {
+		return nil, fmt.Errorf("http sink requires a \"url\" option")
+	}
+
+	return &HTTPSink{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (s *HTTPSink) Write(entry []byte) error {
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(entry))
+	if err != nil {
+		return fmt.Errorf("failed to post entry to %s: %s", s.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with status %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+func (s *HTTPSink) Close() error {
+	return nil
+}
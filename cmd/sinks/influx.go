@@ -0,0 +1,144 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sinks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InfluxSink converts each entry's JSON fields into an InfluxDB
+// line-protocol line and writes it to an InfluxDB HTTP /write endpoint.
+//
+// "tags" maps JSON field names to the tag key they should be written
+// under; every other JSON field becomes a field, with non-scalar values
+// (objects, arrays, null) written as their quoted %v string form.
+type InfluxSink struct {
+	measurement string
+	tags        map[string]string
+	url         string
+	client      *http.Client
+}
+
+func newInfluxSink(options map[string]interface{}) (Sink, error) {
+	measurement := stringOption(options, "measurement", "")
+	if measurement == "" {
+		return nil, fmt.Errorf("influx sink requires a \"measurement\" option")
+	}
+	url := stringOption(options, "url", "")
+	if url == "" {
+		return nil, fmt.Errorf("influx sink requires a \"url\" option (InfluxDB /write endpoint)")
+	}
+
+	return &InfluxSink{
+		measurement: measurement,
+		tags:        stringMapOption(options, "tags"),
+		url:         url,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (s *InfluxSink) Write(entry []byte) error {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(entry, &fields); err != nil {
+		return fmt.Errorf("failed to decode entry as JSON: %s", err)
+	}
+
+	line, err := s.lineProtocol(fields)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.url, "text/plain", strings.NewReader(line))
+	if err != nil {
+		return fmt.Errorf("failed to write line to %s: %s", s.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("influx endpoint %s responded with status %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+func (s *InfluxSink) lineProtocol(fields map[string]interface{}) (string, error) {
+	var tagPairs []string
+	for jsonField, tagKey := range s.tags {
+		if v, ok := fields[jsonField]; ok {
+			tagPairs = append(tagPairs, fmt.Sprintf("%s=%s", escapeTagOrKey(tagKey), escapeTagOrKey(fmt.Sprintf("%v", v))))
+		}
+	}
+	sort.Strings(tagPairs)
+
+	var fieldPairs []string
+	for k, v := range fields {
+		if _, isTag := s.tags[k]; isTag {
+			continue
+		}
+		fieldPairs = append(fieldPairs, fmt.Sprintf("%s=%s", escapeTagOrKey(k), influxFieldValue(v)))
+	}
+	sort.Strings(fieldPairs)
+	if len(fieldPairs) == 0 {
+		return "", fmt.Errorf("entry has no non-tag fields to write")
+	}
+
+	line := escapeMeasurement(s.measurement)
+	if len(tagPairs) > 0 {
+		line += "," + strings.Join(tagPairs, ",")
+	}
+	line += " " + strings.Join(fieldPairs, ",")
+	return line, nil
+}
+
+// escapeMeasurement escapes the commas and spaces that would otherwise be
+// read as the end of the measurement name in line protocol.
+func escapeMeasurement(s string) string {
+	return strings.NewReplacer(",", "\\,", " ", "\\ ").Replace(s)
+}
+
+// escapeTagOrKey escapes the commas, equals signs and spaces that line
+// protocol treats as structural in tag keys, tag values and field keys.
+func escapeTagOrKey(s string) string {
+	return strings.NewReplacer(",", "\\,", "=", "\\=", " ", "\\ ").Replace(s)
+}
+
+// escapeFieldString escapes the quotes and backslashes line protocol
+// requires inside a quoted string field value.
+func escapeFieldString(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+func influxFieldValue(v interface{}) string {
+	switch val := v.(type) {
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case string:
+		return `"` + escapeFieldString(val) + `"`
+	default:
+		return `"` + escapeFieldString(fmt.Sprintf("%v", val)) + `"`
+	}
+}
+
+func (s *InfluxSink) Close() error {
+	return nil
+}
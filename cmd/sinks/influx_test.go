@@ -0,0 +1,65 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sinks
+
+import "testing"
+
+func TestLineProtocolEscapesReservedCharacters(t *testing.T) {
+	sink := &InfluxSink{
+		measurement: "whisper",
+		tags:        map[string]string{"name": "sensor"},
+	}
+
+	line, err := sink.lineProtocol(map[string]interface{}{
+		"name":  "Living Room",
+		"value": "a,b=c",
+	})
+	if err != nil {
+		t.Fatalf("lineProtocol returned error: %s", err)
+	}
+
+	const want = `whisper,sensor=Living\ Room value="a,b=c"`
+	if line != want {
+		t.Errorf("lineProtocol() = %q, want %q", line, want)
+	}
+}
+
+func TestLineProtocolEscapesFieldKeys(t *testing.T) {
+	sink := &InfluxSink{measurement: "whisper"}
+
+	line, err := sink.lineProtocol(map[string]interface{}{
+		"a,b": float64(1),
+	})
+	if err != nil {
+		t.Fatalf("lineProtocol returned error: %s", err)
+	}
+
+	const want = `whisper a\,b=1`
+	if line != want {
+		t.Errorf("lineProtocol() = %q, want %q", line, want)
+	}
+}
+
+func TestLineProtocolRejectsTagOnlyEntries(t *testing.T) {
+	sink := &InfluxSink{
+		measurement: "whisper",
+		tags:        map[string]string{"name": "sensor"},
+	}
+
+	if _, err := sink.lineProtocol(map[string]interface{}{"name": "sensor-42"}); err == nil {
+		t.Fatal("expected an error for an entry with no non-tag fields")
+	}
+}
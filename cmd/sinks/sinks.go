@@ -0,0 +1,138 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sinks routes log store entries to one or more destinations
+// (file, stdout, an HTTP webhook, InfluxDB line protocol, ...), each
+// optionally filtered to only the entries it cares about.
+package sinks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/expr-lang/expr"
+)
+
+// Sink is a single destination entries can be routed to.
+type Sink interface {
+	// Write delivers a single log store entry.
+	Write(entry []byte) error
+	Close() error
+}
+
+// Spec is one entry of the `sinks` config list.
+type Spec struct {
+	Type    string                 `mapstructure:"type"`
+	Filter  string                 `mapstructure:"filter"`
+	Options map[string]interface{} `mapstructure:"options"`
+}
+
+// Routed pairs a Sink with its filter expression, so only matching entries
+// get forwarded to it.
+type Routed struct {
+	Sink
+	filter string
+}
+
+// Matches reports whether entry satisfies this sink's filter expression.
+// A sink with no filter matches everything.
+//
+// The expression is (re)compiled against an expr.Env built from entry's own
+// decoded fields, rather than once up front: entries use field names like
+// "type" that collide with expr's builtin functions, and only declaring
+// them as env variables makes the field win over the builtin.
+func (r *Routed) Matches(entry []byte) bool {
+	if r.filter == "" {
+		return true
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(entry, &fields); err != nil {
+		return false
+	}
+
+	program, err := expr.Compile(r.filter, expr.Env(fields), expr.AllowUndefinedVariables())
+	if err != nil {
+		return false
+	}
+
+	out, err := expr.Run(program, fields)
+	if err != nil {
+		return false
+	}
+	matched, _ := out.(bool)
+	return matched
+}
+
+// Build constructs a Sink for each spec.
+//
+// A filter's syntax isn't validated here: entries commonly use field names
+// (like "type") that collide with expr builtins, and whether a given name
+// resolves to a field or a builtin can only be decided once Matches has
+// real entry fields to build an expr.Env from.
+func Build(specs []Spec) ([]*Routed, error) {
+	routed := make([]*Routed, 0, len(specs))
+	for _, spec := range specs {
+		sink, err := newSink(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		routed = append(routed, &Routed{Sink: sink, filter: spec.Filter})
+	}
+	return routed, nil
+}
+
+func newSink(spec Spec) (Sink, error) {
+	switch spec.Type {
+	case "file":
+		return newFileSink(spec.Options)
+	case "stdout":
+		return newStdoutSink(spec.Options)
+	case "http":
+		return newHTTPSink(spec.Options)
+	case "influx":
+		return newInfluxSink(spec.Options)
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", spec.Type)
+	}
+}
+
+// Dispatch writes entry to every sink in routed whose filter matches it,
+// collecting (rather than aborting on) per-sink write errors.
+func Dispatch(routed []*Routed, entry []byte) []error {
+	var errs []error
+	for _, r := range routed {
+		if !r.Matches(entry) {
+			continue
+		}
+		if err := r.Write(entry); err != nil {
+			errs = append(errs, fmt.Errorf("sink %T: %s", r.Sink, err))
+		}
+	}
+	return errs
+}
+
+// CloseAll closes every sink, collecting rather than aborting on errors.
+func CloseAll(routed []*Routed) []error {
+	var errs []error
+	for _, r := range routed {
+		if err := r.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
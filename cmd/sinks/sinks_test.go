@@ -0,0 +1,52 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sinks
+
+import "testing"
+
+func TestRoutedMatchesFilterExpression(t *testing.T) {
+	routed, err := Build([]Spec{{
+		Type:   "stdout",
+		Filter: `type == "whisper" && name == "sensor-42"`,
+	}})
+	if err != nil {
+		t.Fatalf("Build returned error: %s", err)
+	}
+	if len(routed) != 1 {
+		t.Fatalf("Build returned %d sinks, want 1", len(routed))
+	}
+
+	matching := []byte(`{"type":"whisper","name":"sensor-42"}`)
+	if !routed[0].Matches(matching) {
+		t.Error("Matches() = false for an entry that satisfies the filter")
+	}
+
+	nonMatching := []byte(`{"type":"whisper","name":"sensor-1"}`)
+	if routed[0].Matches(nonMatching) {
+		t.Error("Matches() = true for an entry that doesn't satisfy the filter")
+	}
+}
+
+func TestRoutedMatchesEverythingWithoutAFilter(t *testing.T) {
+	routed, err := Build([]Spec{{Type: "stdout"}})
+	if err != nil {
+		t.Fatalf("Build returned error: %s", err)
+	}
+
+	if !routed[0].Matches([]byte(`{"anything":"goes"}`)) {
+		t.Error("Matches() = false for a sink without a filter")
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright © 2023 David Araújo <davidaraujo98@github.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sinks
+
+import (
+	"fmt"
+	"os"
+)
+
+// StdoutSink prints each entry, one per line, to the process's stdout.
+type StdoutSink struct{}
+
+func newStdoutSink(options map[string]interface{}) (Sink, error) {
+	return &StdoutSink{}, nil
+}
+
+func (s *StdoutSink) Write(entry []byte) error {
+	_, err := fmt.Fprintln(os.Stdout, string(entry))
+	return err
+}
+
+func (s *StdoutSink) Close() error {
+	return nil
+}